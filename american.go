@@ -0,0 +1,94 @@
+package blackscholes
+
+import (
+	"fmt"
+	"math"
+)
+
+// AmericanOption prices an American-exercise option via a Cox-Ross-Rubinstein
+// (CRR) recombining binomial tree. Unlike BlackScholesMerton, it allows early
+// exercise, which matters for American puts and for calls on a
+// dividend-paying underlying near the ex-date. steps controls the tree
+// resolution; 0 defaults to 200.
+func AmericanOption(s, k, t, v, r, q float64, callPut string, steps int) float64 {
+	if steps == 0 {
+		steps = 200
+	}
+	return americanTreePrice(s, k, t, v, r, q, callPut, steps)
+}
+
+// americanTreePrice is the CRR binomial tree core, with no default applied
+// to steps, so callers that need an exact (possibly small) step count, such
+// as AmericanGreeks rolling a sub-tree forward, get it unmodified.
+func americanTreePrice(s, k, t, v, r, q float64, callPut string, steps int) float64 {
+	dt := t / float64(steps)
+	u := math.Exp(v * math.Sqrt(dt))
+	d := 1 / u
+	p := (math.Exp((r-q)*dt) - d) / (u - d)
+	if !isFinite(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	disc := math.Exp(-r * dt)
+
+	values := make([]float64, steps+1)
+	for j := 0; j <= steps; j++ {
+		price := s * math.Pow(u, float64(j)) * math.Pow(d, float64(steps-j))
+		values[j] = exerciseValue(price, k, callPut)
+	}
+
+	for i := steps - 1; i >= 0; i-- {
+		for j := 0; j <= i; j++ {
+			price := s * math.Pow(u, float64(j)) * math.Pow(d, float64(i-j))
+			continuation := disc * (p*values[j+1] + (1-p)*values[j])
+			values[j] = math.Max(exerciseValue(price, k, callPut), continuation)
+		}
+	}
+	return values[0]
+}
+
+// exerciseValue returns the immediate exercise payoff at a given underlying price.
+func exerciseValue(price, k float64, callPut string) float64 {
+	if callPut == "CALL" {
+		return math.Max(0, price-k)
+	}
+	return math.Max(0, k-price)
+}
+
+// AmericanGreeks computes delta and gamma for an American option by finite
+// differences over the three CRR tree nodes two steps from the root (S*u*u,
+// S, S*d*d). Each of those node values is obtained by pricing a separate
+// (steps-2)-step sub-tree from that node, so a call costs about three tree
+// prices; the values are not read off a single rollback of one tree.
+// steps controls the tree resolution; 0 defaults to 200.
+func AmericanGreeks(s, k, t, v, r, q float64, callPut string, steps int) (delta, gamma float64, err error) {
+	if callPut != "CALL" && callPut != "PUT" {
+		return 0, 0, fmt.Errorf("callput is not of type CALL or PUT %s", callPut)
+	}
+	if steps == 0 {
+		steps = 200
+	}
+	if steps < 3 {
+		return 0, 0, fmt.Errorf("steps must be at least 3 to compute greeks, got %d", steps)
+	}
+
+	dt := t / float64(steps)
+	u := math.Exp(v * math.Sqrt(dt))
+	d := 1 / u
+	p := (math.Exp((r-q)*dt) - d) / (u - d)
+	if !isFinite(p) || p < 0 || p > 1 {
+		return math.NaN(), math.NaN(), nil
+	}
+
+	remaining := steps - 2
+	tRemaining := t - 2*dt
+	sUU := s * u * u
+	sUD := s
+	sDD := s * d * d
+	vUU := americanTreePrice(sUU, k, tRemaining, v, r, q, callPut, remaining)
+	vUD := americanTreePrice(sUD, k, tRemaining, v, r, q, callPut, remaining)
+	vDD := americanTreePrice(sDD, k, tRemaining, v, r, q, callPut, remaining)
+
+	delta = (vUU - vDD) / (sUU - sDD)
+	gamma = ((vUU-vUD)/(sUU-sUD) - (vUD-vDD)/(sUD-sDD)) / ((sUU - sDD) / 2)
+	return delta, gamma, nil
+}
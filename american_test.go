@@ -0,0 +1,98 @@
+package blackscholes
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAmericanOptionConvergesToEuropean(t *testing.T) {
+	// With no dividend yield, an American call is never early-exercised, so
+	// it should converge to the European (Black-Scholes) price.
+	s, k, tExp, v, r, q := 100.0, 100.0, 1.0, 0.2, 0.05, 0.0
+	american := AmericanOption(s, k, tExp, v, r, q, "CALL", 200)
+	european := BlackScholesMerton(s, k, tExp, v, r, q, "CALL")
+
+	if math.Abs(american-european) > 0.05 {
+		t.Fatalf("American call price %f should be close to European price %f", american, european)
+	}
+}
+
+func TestAmericanPutExceedsEuropeanPut(t *testing.T) {
+	// Early-exercise value means an American put must be worth at least as
+	// much as its European counterpart.
+	s, k, tExp, v, r, q := 100.0, 120.0, 1.0, 0.2, 0.05, 0.0
+	american := AmericanOption(s, k, tExp, v, r, q, "PUT", 200)
+	european := BlackScholesMerton(s, k, tExp, v, r, q, "PUT")
+
+	if american < european {
+		t.Fatalf("American put price %f should be >= European put price %f", american, european)
+	}
+}
+
+func TestAmericanCallWithDividendExceedsEuropean(t *testing.T) {
+	// With a non-zero dividend yield, early exercise of an American call can
+	// be optimal just before an ex-dividend date, so its price must exceed
+	// the European price that ignores that option.
+	s, k, tExp, v, r, q := 100.0, 100.0, 1.0, 0.2, 0.03, 0.06
+	american := AmericanOption(s, k, tExp, v, r, q, "CALL", 200)
+	european := BlackScholesMerton(s, k, tExp, v, r, q, "CALL")
+
+	if american < european {
+		t.Fatalf("American call price %f should be >= European call price %f with q=%.2f", american, european, q)
+	}
+	if math.Abs(american-european) < 1e-6 {
+		t.Fatalf("American call price %f should be strictly greater than European price %f with q=%.2f", american, european, q)
+	}
+}
+
+func TestAmericanGreeksMatchesFiniteDifference(t *testing.T) {
+	// Pin AmericanGreeks against a finite difference on AmericanOption
+	// itself, the way TestMertonDividendYield does for the European Greeks.
+	cases := []struct {
+		name    string
+		s, k, t float64
+		v, r, q float64
+		callPut string
+		steps   int
+	}{
+		{
+			name: "CALL no dividend", s: 100, k: 100, t: 1.0,
+			v: 0.20, r: 0.05, q: 0.0, callPut: "CALL", steps: 1000,
+		},
+		{
+			name: "PUT with dividend (early exercise)", s: 100, k: 120, t: 1.0,
+			v: 0.20, r: 0.03, q: 0.06, callPut: "PUT", steps: 1000,
+		},
+	}
+
+	const h = 3.0
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			delta, gamma, err := AmericanGreeks(tc.s, tc.k, tc.t, tc.v, tc.r, tc.q, tc.callPut, tc.steps)
+			if err != nil {
+				t.Fatalf("AmericanGreeks() returned error: %v", err)
+			}
+
+			up := AmericanOption(tc.s+h, tc.k, tc.t, tc.v, tc.r, tc.q, tc.callPut, tc.steps)
+			mid := AmericanOption(tc.s, tc.k, tc.t, tc.v, tc.r, tc.q, tc.callPut, tc.steps)
+			down := AmericanOption(tc.s-h, tc.k, tc.t, tc.v, tc.r, tc.q, tc.callPut, tc.steps)
+
+			fdDelta := (up - down) / (2 * h)
+			fdGamma := (up - 2*mid + down) / (h * h)
+
+			if diff := math.Abs(delta - fdDelta); diff > 5e-3 {
+				t.Fatalf("delta = %.6f, finite-difference = %.6f (diff %.6f)", delta, fdDelta, diff)
+			}
+			if diff := math.Abs(gamma - fdGamma); diff > 5e-3 {
+				t.Fatalf("gamma = %.6f, finite-difference = %.6f (diff %.6f)", gamma, fdGamma, diff)
+			}
+		})
+	}
+}
+
+func TestAmericanGreeksRejectsBadCallPut(t *testing.T) {
+	_, _, err := AmericanGreeks(100, 100, 1.0, 0.2, 0.05, 0.0, "BOGUS", 200)
+	if err == nil {
+		t.Fatalf("expected error for invalid callPut")
+	}
+}
@@ -40,7 +40,7 @@ func TestCalculateGetDelta(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			delta, _ := GetDelta(tt.underlyingValue, tt.strike, tt.timeToExpiry, tt.volatility, tt.riskFreeRate, tt.contractType)
+			delta, _ := GetDelta(tt.underlyingValue, tt.strike, tt.timeToExpiry, tt.volatility, tt.riskFreeRate, 0, tt.contractType)
 			if delta == 0 {
 				t.Errorf("calculateDelta() returned zero delta")
 			}
@@ -52,6 +52,31 @@ func TestCalculateGetDelta(t *testing.T) {
 	}
 }
 
+// TestGetDeltaDegenerateVolatilityWithDividend pins the v==0 (deterministic
+// price) branch of callDelta/putDelta to stay consistent with
+// BlackScholesMerton once q != 0: a deterministic ITM call's delta should be
+// exp(-q*t), not a bare 1, and the matching put's delta should be 0.
+func TestGetDeltaDegenerateVolatilityWithDividend(t *testing.T) {
+	s, k, timeToExpiry, r, q := 100.0, 90.0, 1.0, 0.05, 0.1
+	wantITM := math.Exp(-q * timeToExpiry)
+
+	callDelta, err := GetDelta(s, k, timeToExpiry, 0, r, q, "CALL")
+	if err != nil {
+		t.Fatalf("GetDelta() returned error: %v", err)
+	}
+	if diff := math.Abs(callDelta - wantITM); diff > 1e-9 {
+		t.Fatalf("deterministic ITM call delta = %.10f, want %.10f (diff %.10f)", callDelta, wantITM, diff)
+	}
+
+	putDelta, err := GetDelta(s, k, timeToExpiry, 0, r, q, "PUT")
+	if err != nil {
+		t.Fatalf("GetDelta() returned error: %v", err)
+	}
+	if diff := math.Abs(putDelta - 0); diff > 1e-9 {
+		t.Fatalf("deterministic OTM put delta = %.10f, want 0", putDelta)
+	}
+}
+
 func TestCalculateIV(t *testing.T) {
 	tests := []struct {
 		name            string
@@ -78,7 +103,10 @@ func TestCalculateIV(t *testing.T) {
 	// func BSImpliedVol(callType bool, lastTradedPrice float64, underlying float64, strike float64, timeToExpiration float64, startAnchorVolatility float64, riskFreeInterest float64, dividend float64) float64 {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			iv := GetImpliedVolatility(tt.premium, tt.strike, tt.underlyingValue, tt.timeToExpiry, tt.riskFreeRate, tt.contractType, 0)
+			iv, err := GetImpliedVolatility(tt.premium, tt.strike, tt.underlyingValue, tt.timeToExpiry, tt.riskFreeRate, 0, tt.contractType, IVOptions{})
+			if err != nil {
+				t.Fatalf("GetImpliedVolatility() returned error: %v", err)
+			}
 			if iv == 0 {
 				t.Errorf("calculateDelta() returned zero delta")
 			}
@@ -89,3 +117,49 @@ func TestCalculateIV(t *testing.T) {
 		})
 	}
 }
+
+// TestMertonDividendYield pins the q (dividend yield / cost-of-carry) term
+// added in BlackScholesMerton and the Greeks: put-call parity must still
+// hold, and delta/theta/rho must still match a central finite difference
+// on price once q is non-zero.
+func TestMertonDividendYield(t *testing.T) {
+	s, k, timeToExpiry, v, r, q := 100.0, 100.0, 0.5, 0.20, 0.05, 0.06
+
+	t.Run("putCallParity", func(t *testing.T) {
+		call := BlackScholesMerton(s, k, timeToExpiry, v, r, q, "CALL")
+		put := BlackScholesMerton(s, k, timeToExpiry, v, r, q, "PUT")
+		parity := s*math.Exp(-q*timeToExpiry) - k*math.Exp(-r*timeToExpiry)
+		if diff := math.Abs((call - put) - parity); diff > 1e-6 {
+			t.Fatalf("put-call parity violated with q=%.2f: C-P=%.8f, want %.8f (diff %.8f)", q, call-put, parity, diff)
+		}
+	})
+
+	const h = 1e-4
+
+	t.Run("delta", func(t *testing.T) {
+		fd := (BlackScholesMerton(s+h, k, timeToExpiry, v, r, q, "CALL") - BlackScholesMerton(s-h, k, timeToExpiry, v, r, q, "CALL")) / (2 * h)
+		delta, err := GetDelta(s, k, timeToExpiry, v, r, q, "CALL")
+		if err != nil {
+			t.Fatalf("GetDelta() returned error: %v", err)
+		}
+		if diff := math.Abs(fd - delta); diff > 1e-3 {
+			t.Fatalf("delta mismatch with q=%.2f: finite-difference=%.6f, GetDelta=%.6f (diff %.6f)", q, fd, delta, diff)
+		}
+	})
+
+	t.Run("theta", func(t *testing.T) {
+		fd := -(BlackScholesMerton(s, k, timeToExpiry+h, v, r, q, "CALL") - BlackScholesMerton(s, k, timeToExpiry-h, v, r, q, "CALL")) / (2 * h)
+		theta := GetTheta(s, k, timeToExpiry, v, r, q, "call", 1)
+		if diff := math.Abs(fd - theta); diff > 1e-2 {
+			t.Fatalf("theta mismatch with q=%.2f: finite-difference=%.6f, GetTheta=%.6f (diff %.6f)", q, fd, theta, diff)
+		}
+	})
+
+	t.Run("rho", func(t *testing.T) {
+		fd := (BlackScholesMerton(s, k, timeToExpiry, v, r+h, q, "CALL") - BlackScholesMerton(s, k, timeToExpiry, v, r-h, q, "CALL")) / (2 * h)
+		rho := GetRho(s, k, timeToExpiry, v, r, q, "call", 1)
+		if diff := math.Abs(fd - rho); diff > 1e-3 {
+			t.Fatalf("rho mismatch with q=%.2f: finite-difference=%.6f, GetRho=%.6f (diff %.6f)", q, fd, rho, diff)
+		}
+	})
+}
@@ -8,46 +8,60 @@ func isFinite(f float64) bool {
 	return !math.IsInf(f, 0) && !math.IsNaN(f)
 }
 
+// Hastings/Abramowitz-Stegun 26.2.17 rational approximation coefficients for StdNormCDF.
+const (
+	stdNormCDFb1 = 0.319381530
+	stdNormCDFb2 = -0.356563782
+	stdNormCDFb3 = 1.781477937
+	stdNormCDFb4 = -1.821255978
+	stdNormCDFb5 = 1.330274429
+	stdNormCDFp  = 0.2316419
+)
+
 // StdNormCDF calculates the standard normal cumulative distribution function
+// using the Hastings/Abramowitz-Stegun 26.2.17 rational approximation
+// (max error ~7.5e-8), which is O(1) per call and stable across the full
+// range of x instead of the catastrophic cancellation a truncated Taylor
+// series suffers for |x| in roughly the 3-8 range.
 func StdNormCDF(x float64) float64 {
-	var probability float64
 	if x >= 8 {
-		probability = 1
+		return 1
 	} else if x <= -8 {
-		probability = 0
-	} else {
-		for i := 0; i < 100; i++ {
-			probability += (math.Pow(x, float64(2*i+1)) / doubleFactorial(float64(2*i+1)))
-		}
-		probability *= math.Exp(-0.5 * math.Pow(x, 2))
-		probability /= math.Sqrt(2 * math.Pi)
-		probability += 0.5
+		return 0
 	}
-	return probability
-}
-
-// DoubleFactorial calculates the double factorial of n
-func doubleFactorial(n float64) float64 {
-	val := 1.0
-	for i := n; i > 1; i -= 2 {
-		val *= i
+	ax := math.Abs(x)
+	t := 1 / (1 + stdNormCDFp*ax)
+	n := StdNormDensity(ax) * ((((stdNormCDFb5*t+stdNormCDFb4)*t+stdNormCDFb3)*t+stdNormCDFb2)*t + stdNormCDFb1) * t
+	if x >= 0 {
+		return 1 - n
 	}
-	return val
+	return n
 }
 
-// BlackScholes calculates the Black-Scholes option pricing formula
+// BlackScholes calculates the Black-Scholes option pricing formula for a
+// non-dividend-paying underlying. It is a thin wrapper around
+// BlackScholesMerton with the dividend yield (cost-of-carry drag) set to zero.
 func BlackScholes(s, k, t, v, r float64, callPut string) float64 {
+	return BlackScholesMerton(s, k, t, v, r, 0, callPut)
+}
+
+// BlackScholesMerton calculates option price using the Merton (1973)
+// generalization of Black-Scholes that adds a continuous dividend yield q.
+// Setting q=0 recovers plain Black-Scholes, q=r prices options on futures
+// (Black-76), and q=r_foreign prices FX options.
+func BlackScholesMerton(s, k, t, v, r, q float64, callPut string) float64 {
 	var price float64
-	w := (r*t + math.Pow(v, 2)*t/2 - math.Log(k/s)) / (v * math.Sqrt(t))
+	w := GetW(s, k, t, v, r, q)
 	if callPut == "CALL" {
-		price = s*StdNormCDF(w) - k*math.Exp(-r*t)*StdNormCDF(w-v*math.Sqrt(t))
+		price = s*math.Exp(-q*t)*StdNormCDF(w) - k*math.Exp(-r*t)*StdNormCDF(w-v*math.Sqrt(t))
 	} else {
-		price = k*math.Exp(-r*t)*StdNormCDF(v*math.Sqrt(t)-w) - s*StdNormCDF(-w)
+		price = k*math.Exp(-r*t)*StdNormCDF(v*math.Sqrt(t)-w) - s*math.Exp(-q*t)*StdNormCDF(-w)
 	}
 	return price
 }
 
-// GetW calculates omega as defined in the Black-Scholes formula
-func GetW(s, k, t, v, r float64) float64 {
-	return (r*t + math.Pow(v, 2)*t/2 - math.Log(k/s)) / (v * math.Sqrt(t))
+// GetW calculates omega (d1) as defined in the generalized Black-Scholes
+// formula, using cost-of-carry b = r - q.
+func GetW(s, k, t, v, r, q float64) float64 {
+	return (math.Log(s/k) + (r-q+math.Pow(v, 2)/2)*t) / (v * math.Sqrt(t))
 }
@@ -11,6 +11,7 @@ import (
 // T := 1.0     // Time to maturity (in years)
 // r := 0.05    // Risk-free interest rate
 // sigma := 0.2 // Volatility
+// q := 0.0     // Continuous dividend yield / cost-of-carry drag
 
 // StdNormDensity calculates the standard normal density function
 func StdNormDensity(x float64) float64 {
@@ -18,34 +19,34 @@ func StdNormDensity(x float64) float64 {
 }
 
 // GetDelta calculates the delta of an option
-func GetDelta(s, k, t, v, r float64, callPut string) (float64, error) {
+func GetDelta(s, k, t, v, r, q float64, callPut string) (float64, error) {
 	if callPut == "CALL" {
-		return callDelta(s, k, t, v, r), nil
+		return callDelta(s, k, t, v, r, q), nil
 	} else if callPut == "PUT" {
-		return putDelta(s, k, t, v, r), nil
+		return putDelta(s, k, t, v, r, q), nil
 	}
 	return 0, fmt.Errorf("callput is not of type CALL or PUT %s", callPut)
 }
 
 // CallDelta calculates the delta of a call option
-func callDelta(s, k, t, v, r float64) float64 {
-	w := GetW(s, k, t, v, r)
+func callDelta(s, k, t, v, r, q float64) float64 {
+	w := GetW(s, k, t, v, r, q)
 	var delta float64
 	if !isFinite(w) {
 		if s > k {
-			delta = 1
+			delta = math.Exp(-q * t)
 		} else {
 			delta = 0
 		}
 	} else {
-		delta = StdNormCDF(w)
+		delta = math.Exp(-q*t) * StdNormCDF(w)
 	}
 	return delta
 }
 
 // PutDelta calculates the delta of a put option
-func putDelta(s, k, t, v, r float64) float64 {
-	delta := callDelta(s, k, t, v, r) - 1
+func putDelta(s, k, t, v, r, q float64) float64 {
+	delta := callDelta(s, k, t, v, r, q) - math.Exp(-q*t)
 	if delta == -1 && k == s {
 		return 0
 	}
@@ -53,19 +54,19 @@ func putDelta(s, k, t, v, r float64) float64 {
 }
 
 // GetRho calculates the rho of an option
-func GetRho(s, k, t, v, r float64, callPut string, scale int) float64 {
+func GetRho(s, k, t, v, r, q float64, callPut string, scale int) float64 {
 	if scale == 0 {
 		scale = 100
 	}
 	if callPut == "call" {
-		return callRho(s, k, t, v, r) / float64(scale)
+		return callRho(s, k, t, v, r, q) / float64(scale)
 	}
-	return putRho(s, k, t, v, r) / float64(scale)
+	return putRho(s, k, t, v, r, q) / float64(scale)
 }
 
 // CallRho calculates the rho of a call option
-func callRho(s, k, t, v, r float64) float64 {
-	w := GetW(s, k, t, v, r)
+func callRho(s, k, t, v, r, q float64) float64 {
+	w := GetW(s, k, t, v, r, q)
 	if !math.IsNaN(w) {
 		return k * t * math.Exp(-r*t) * StdNormCDF(w-v*math.Sqrt(t))
 	}
@@ -73,57 +74,65 @@ func callRho(s, k, t, v, r float64) float64 {
 }
 
 // PutRho calculates the rho of a put option
-func putRho(s, k, t, v, r float64) float64 {
-	w := GetW(s, k, t, v, r)
+func putRho(s, k, t, v, r, q float64) float64 {
+	w := GetW(s, k, t, v, r, q)
 	if !math.IsNaN(w) {
 		return -k * t * math.Exp(-r*t) * StdNormCDF(v*math.Sqrt(t)-w)
 	}
 	return 0
 }
 
-// GetVega calculates the vega of a call and put option
-func GetVega(s, k, t, v, r float64) float64 {
-	w := GetW(s, k, t, v, r)
+// GetVega calculates the vega of a call and put option, scaled to the price
+// change per 1 percentage-point move in volatility.
+func GetVega(s, k, t, v, r, q float64) float64 {
+	return vega(s, k, t, v, r, q) / 100
+}
+
+// vega calculates the raw (unscaled) analytical derivative of price with
+// respect to volatility, d(price)/d(sigma). Used internally by the implied
+// volatility solver's Newton step.
+func vega(s, k, t, v, r, q float64) float64 {
+	w := GetW(s, k, t, v, r, q)
 	if isFinite(w) {
-		return s * math.Sqrt(t) * StdNormDensity(w) / 100
+		return s * math.Exp(-q*t) * math.Sqrt(t) * StdNormDensity(w)
 	}
 	return 0
 }
 
 // GetTheta calculates the theta of an option
-func GetTheta(s, k, t, v, r float64, callPut string, scale int) float64 {
+func GetTheta(s, k, t, v, r, q float64, callPut string, scale int) float64 {
 	if scale == 0 {
 		scale = 365
 	}
 	if callPut == "call" {
-		return callTheta(s, k, t, v, r) / float64(scale)
+		return callTheta(s, k, t, v, r, q) / float64(scale)
 	}
-	return putTheta(s, k, t, v, r) / float64(scale)
+	return putTheta(s, k, t, v, r, q) / float64(scale)
 }
 
 // CallTheta calculates the theta of a call option
-func callTheta(s, k, t, v, r float64) float64 {
-	w := GetW(s, k, t, v, r)
+func callTheta(s, k, t, v, r, q float64) float64 {
+	w := GetW(s, k, t, v, r, q)
 	if isFinite(w) {
-		return -v*s*StdNormDensity(w)/(2*math.Sqrt(t)) - k*r*math.Exp(-r*t)*StdNormCDF(w-v*math.Sqrt(t))
+		return -v*s*math.Exp(-q*t)*StdNormDensity(w)/(2*math.Sqrt(t)) - r*k*math.Exp(-r*t)*StdNormCDF(w-v*math.Sqrt(t)) + q*s*math.Exp(-q*t)*StdNormCDF(w)
 	}
 	return 0
 }
 
 // PutTheta calculates the theta of a put option
-func putTheta(s, k, t, v, r float64) float64 {
-	w := GetW(s, k, t, v, r)
+func putTheta(s, k, t, v, r, q float64) float64 {
+	w := GetW(s, k, t, v, r, q)
 	if isFinite(w) {
-		return -v*s*StdNormDensity(w)/(2*math.Sqrt(t)) + k*r*math.Exp(-r*t)*StdNormCDF(v*math.Sqrt(t)-w)
+		return -v*s*math.Exp(-q*t)*StdNormDensity(w)/(2*math.Sqrt(t)) + r*k*math.Exp(-r*t)*StdNormCDF(v*math.Sqrt(t)-w) - q*s*math.Exp(-q*t)*StdNormCDF(-w)
 	}
 	return 0
 }
 
 // GetGamma calculates the gamma of a call and put option
-func GetGamma(s, k, t, v, r float64) float64 {
-	w := GetW(s, k, t, v, r)
+func GetGamma(s, k, t, v, r, q float64) float64 {
+	w := GetW(s, k, t, v, r, q)
 	if isFinite(w) {
-		return StdNormDensity(w) / (s * v * math.Sqrt(t))
+		return math.Exp(-q*t) * StdNormDensity(w) / (s * v * math.Sqrt(t))
 	}
 	return 0
 }
@@ -1,31 +1,214 @@
 package blackscholes
 
 import (
+	"errors"
 	"math"
 )
 
-// GetImpliedVolatility calculates a close estimate of implied volatility given an option price
-func GetImpliedVolatility(expectedCost, s, k, t, r float64, callPut string, estimate float64) float64 {
-	if estimate == 0 {
-		estimate = 0.1
-	}
-	low := 0.0
-	high := math.Inf(1)
-	// perform 100 iterations max
-	for i := 0; i < 100; i++ {
-		actualCost := BlackScholes(s, k, t, estimate, r, callPut)
-		if int(expectedCost*100) == int(actualCost*100) {
-			break
-		} else if actualCost > expectedCost {
-			high = estimate
-			estimate = (estimate-low)/2 + low
+// Errors returned by GetImpliedVolatility.
+var (
+	// ErrPriceBelowIntrinsic is returned when the quoted price is below the
+	// option's intrinsic value, which cannot happen without arbitrage.
+	ErrPriceBelowIntrinsic = errors.New("blackscholes: price is below intrinsic value")
+	// ErrPriceAboveMax is returned when the quoted price exceeds the
+	// no-arbitrage maximum (the discounted underlying or strike leg).
+	ErrPriceAboveMax = errors.New("blackscholes: price exceeds no-arbitrage maximum")
+	// ErrNoConvergence is returned when the solver exhausts MaxIter without
+	// reaching Tolerance, or, for the Brent method, when [minVol, maxVol]
+	// does not bracket the price.
+	ErrNoConvergence = errors.New("blackscholes: implied volatility did not converge")
+)
+
+// Method selects the root-finding algorithm used by GetImpliedVolatility.
+type Method int
+
+const (
+	// Newton uses Newton-Raphson seeded by the Brenner-Subrahmanyam
+	// approximation, with the unscaled vega (the derivative behind GetVega)
+	// as the analytical derivative. It falls back to bisecting the current
+	// [low, high] bracket (a midpoint step, not a Brent interpolation step)
+	// whenever a Newton step would leave that bracket or vega is too small
+	// to trust.
+	Newton Method = iota
+	// Brent uses Brent's method (inverse quadratic interpolation / secant,
+	// safeguarded by bisection) directly.
+	Brent
+	// Bisection uses plain bisection over [minVol, maxVol].
+	Bisection
+)
+
+// IVOptions configures GetImpliedVolatility.
+type IVOptions struct {
+	// Tolerance is the maximum acceptable |BlackScholesMerton(sigma) - price|.
+	// Defaults to 1e-8.
+	Tolerance float64
+	// MaxIter caps the number of solver iterations. Defaults to 100.
+	MaxIter int
+	// InitialGuess seeds the Newton solver; Brent and Bisection ignore it.
+	// If zero, the Brenner-Subrahmanyam approximation
+	// sigma0 ~= sqrt(2*pi/T) * price/S is used.
+	InitialGuess float64
+	// Method selects the solving algorithm. Defaults to Newton.
+	Method Method
+}
+
+// minVol and maxVol bound the volatility bracket handed to the solvers; no
+// quoted option implies a volatility outside this range.
+const (
+	minVol = 1e-6
+	maxVol = 5.0
+)
+
+// GetImpliedVolatility solves for the volatility that reprices the option at
+// `price` under the generalized (dividend/cost-of-carry aware) Black-Scholes
+// formula, returning a typed error instead of a best-effort guess when the
+// price is outside no-arbitrage bounds or the solver fails to converge.
+func GetImpliedVolatility(price, s, k, t, r, q float64, callPut string, opts IVOptions) (float64, error) {
+	if opts.Tolerance == 0 {
+		opts.Tolerance = 1e-8
+	}
+	if opts.MaxIter == 0 {
+		opts.MaxIter = 100
+	}
+
+	intrinsic, max := intrinsicBounds(s, k, t, r, q, callPut)
+	if price < intrinsic-opts.Tolerance {
+		return 0, ErrPriceBelowIntrinsic
+	}
+	if price > max+opts.Tolerance {
+		return 0, ErrPriceAboveMax
+	}
+
+	switch opts.Method {
+	case Brent:
+		return solveBrent(price, s, k, t, r, q, callPut, opts)
+	case Bisection:
+		return solveBisection(price, s, k, t, r, q, callPut, opts)
+	default:
+		guess := opts.InitialGuess
+		if guess == 0 {
+			guess = math.Sqrt(2*math.Pi/t) * price / s
+		}
+		if !isFinite(guess) || guess <= minVol || guess >= maxVol {
+			guess = 0.2
+		}
+		return solveNewton(price, s, k, t, r, q, callPut, guess, opts)
+	}
+}
+
+// intrinsicBounds returns the option's intrinsic value and the no-arbitrage
+// maximum price (the value at infinite volatility).
+func intrinsicBounds(s, k, t, r, q float64, callPut string) (intrinsic, max float64) {
+	discS := s * math.Exp(-q*t)
+	discK := k * math.Exp(-r*t)
+	if callPut == "CALL" {
+		return math.Max(0, discS-discK), discS
+	}
+	return math.Max(0, discK-discS), discK
+}
+
+// solveNewton runs Newton-Raphson with the unscaled vega as the analytical derivative,
+// falling back to a bracket bisection step whenever the Newton step would
+// leave the current [low, high] bracket or vega is too small to trust.
+func solveNewton(price, s, k, t, r, q float64, callPut string, guess float64, opts IVOptions) (float64, error) {
+	low, high := minVol, maxVol
+	sigma := guess
+	for i := 0; i < opts.MaxIter; i++ {
+		priceAt := BlackScholesMerton(s, k, t, sigma, r, q, callPut)
+		diff := priceAt - price
+		if math.Abs(diff) < opts.Tolerance {
+			return sigma, nil
+		}
+		if diff > 0 {
+			high = sigma
+		} else {
+			low = sigma
+		}
+
+		v := vega(s, k, t, sigma, r, q)
+		next := sigma - diff/v
+		if v < 1e-8 || !isFinite(next) || next <= low || next >= high {
+			next = (low + high) / 2
+		}
+		sigma = next
+	}
+	return sigma, ErrNoConvergence
+}
+
+// solveBrent finds sigma via Brent's method on f(sigma) = BlackScholesMerton(sigma) - price.
+func solveBrent(price, s, k, t, r, q float64, callPut string, opts IVOptions) (float64, error) {
+	f := func(sigma float64) float64 {
+		return BlackScholesMerton(s, k, t, sigma, r, q, callPut) - price
+	}
+
+	a, b := minVol, maxVol
+	fa, fb := f(a), f(b)
+	if fa*fb > 0 {
+		return 0, ErrNoConvergence
+	}
+	if math.Abs(fa) < math.Abs(fb) {
+		a, b = b, a
+		fa, fb = fb, fa
+	}
+	c, fc := a, fa
+	mflag := true
+	d := a
+
+	for i := 0; i < opts.MaxIter; i++ {
+		if math.Abs(fb) < opts.Tolerance {
+			return b, nil
+		}
+
+		var s2 float64
+		if fa != fc && fb != fc {
+			s2 = a*fb*fc/((fa-fb)*(fa-fc)) + b*fa*fc/((fb-fa)*(fb-fc)) + c*fa*fb/((fc-fa)*(fc-fb))
+		} else {
+			s2 = b - fb*(b-a)/(fb-fa)
+		}
+
+		needsBisect := (s2 < (3*a+b)/4 || s2 > b) ||
+			(mflag && math.Abs(s2-b) >= math.Abs(b-c)/2) ||
+			(!mflag && math.Abs(s2-b) >= math.Abs(c-d)/2) ||
+			(mflag && math.Abs(b-c) < opts.Tolerance) ||
+			(!mflag && math.Abs(c-d) < opts.Tolerance)
+		if needsBisect {
+			s2 = (a + b) / 2
+			mflag = true
+		} else {
+			mflag = false
+		}
+
+		fs := f(s2)
+		d = c
+		c, fc = b, fb
+		if fa*fs < 0 {
+			b, fb = s2, fs
+		} else {
+			a, fa = s2, fs
+		}
+		if math.Abs(fa) < math.Abs(fb) {
+			a, b = b, a
+			fa, fb = fb, fa
+		}
+	}
+	return b, ErrNoConvergence
+}
+
+// solveBisection finds sigma via plain bisection over [minVol, maxVol].
+func solveBisection(price, s, k, t, r, q float64, callPut string, opts IVOptions) (float64, error) {
+	low, high := minVol, maxVol
+	mid := (low + high) / 2
+	for i := 0; i < opts.MaxIter; i++ {
+		mid = (low + high) / 2
+		diff := BlackScholesMerton(s, k, t, mid, r, q, callPut) - price
+		if math.Abs(diff) < opts.Tolerance {
+			return mid, nil
+		}
+		if diff > 0 {
+			high = mid
 		} else {
-			low = estimate
-			estimate = (high-estimate)/2 + estimate
-			if !isFinite(estimate) {
-				estimate = low * 2
-			}
+			low = mid
 		}
 	}
-	return estimate
+	return mid, ErrNoConvergence
 }
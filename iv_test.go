@@ -0,0 +1,53 @@
+package blackscholes
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestGetImpliedVolatilityRejectsPriceBelowIntrinsic(t *testing.T) {
+	s, k, tExp, r, q := 100.0, 80.0, 0.5, 0.05, 0.0
+	intrinsic, _ := intrinsicBounds(s, k, tExp, r, q, "CALL")
+
+	_, err := GetImpliedVolatility(intrinsic-1, s, k, tExp, r, q, "CALL", IVOptions{})
+	if !errors.Is(err, ErrPriceBelowIntrinsic) {
+		t.Fatalf("GetImpliedVolatility() error = %v, want ErrPriceBelowIntrinsic", err)
+	}
+}
+
+func TestGetImpliedVolatilityRejectsPriceAboveMax(t *testing.T) {
+	s, k, tExp, r, q := 100.0, 80.0, 0.5, 0.05, 0.0
+	_, max := intrinsicBounds(s, k, tExp, r, q, "CALL")
+
+	_, err := GetImpliedVolatility(max+1, s, k, tExp, r, q, "CALL", IVOptions{})
+	if !errors.Is(err, ErrPriceAboveMax) {
+		t.Fatalf("GetImpliedVolatility() error = %v, want ErrPriceAboveMax", err)
+	}
+}
+
+func TestGetImpliedVolatilityMethods(t *testing.T) {
+	s, k, tExp, v, r, q := 100.0, 100.0, 0.5, 0.20, 0.05, 0.0
+	price := BlackScholesMerton(s, k, tExp, v, r, q, "CALL")
+
+	methods := []struct {
+		name   string
+		method Method
+	}{
+		{"Newton", Newton},
+		{"Brent", Brent},
+		{"Bisection", Bisection},
+	}
+
+	for _, m := range methods {
+		t.Run(m.name, func(t *testing.T) {
+			iv, err := GetImpliedVolatility(price, s, k, tExp, r, q, "CALL", IVOptions{Method: m.method})
+			if err != nil {
+				t.Fatalf("GetImpliedVolatility() with Method=%s returned error: %v", m.name, err)
+			}
+			if diff := math.Abs(iv - v); diff > 1e-4 {
+				t.Fatalf("GetImpliedVolatility() with Method=%s = %f, want close to %f (diff %.6f)", m.name, iv, v, diff)
+			}
+		})
+	}
+}
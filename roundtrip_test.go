@@ -8,13 +8,14 @@ import (
 // TestRoundTripAccuracy tests that GetPrice -> GetImpliedVolatility -> GetPrice gives the same result
 func TestRoundTripAccuracy(t *testing.T) {
 	testCases := []struct {
-		name         string
-		underlying   float64
-		strike       float64
-		timeToExpiry float64
-		volatility   float64
-		riskFreeRate float64
-		contractType string
+		name          string
+		underlying    float64
+		strike        float64
+		timeToExpiry  float64
+		volatility    float64
+		riskFreeRate  float64
+		dividendYield float64
+		contractType  string
 	}{
 		{
 			name:         "ATM Call - 30 days",
@@ -70,40 +71,55 @@ func TestRoundTripAccuracy(t *testing.T) {
 			riskFreeRate: 0.05,
 			contractType: "PUT",
 		},
+		{
+			name:          "Dividend-paying ATM call",
+			underlying:    100.0,
+			strike:        100.0,
+			timeToExpiry:  0.5,
+			volatility:    0.20,
+			riskFreeRate:  0.05,
+			dividendYield: 0.06,
+			contractType:  "CALL",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			// Step 1: Calculate theoretical price
-			originalPrice := BlackScholes(tc.underlying, tc.strike, tc.timeToExpiry, tc.volatility, tc.riskFreeRate, tc.contractType)
-			
+			originalPrice := BlackScholesMerton(tc.underlying, tc.strike, tc.timeToExpiry, tc.volatility, tc.riskFreeRate, tc.dividendYield, tc.contractType)
+
 			// Step 2: Calculate implied volatility from that price
-			impliedVol := GetImpliedVolatility(originalPrice, tc.underlying, tc.strike, tc.timeToExpiry, tc.riskFreeRate, tc.contractType, 0.0)
-			
+			impliedVol, err := GetImpliedVolatility(originalPrice, tc.underlying, tc.strike, tc.timeToExpiry, tc.riskFreeRate, tc.dividendYield, tc.contractType, IVOptions{})
+			if err != nil {
+				t.Fatalf("GetImpliedVolatility() returned error: %v", err)
+			}
+
 			// Step 3: Calculate price again using implied volatility
-			roundTripPrice := BlackScholes(tc.underlying, tc.strike, tc.timeToExpiry, impliedVol, tc.riskFreeRate, tc.contractType)
-			
+			roundTripPrice := BlackScholesMerton(tc.underlying, tc.strike, tc.timeToExpiry, impliedVol, tc.riskFreeRate, tc.dividendYield, tc.contractType)
+
 			// Check accuracy
 			priceDiff := math.Abs(originalPrice - roundTripPrice)
 			volDiff := math.Abs(tc.volatility - impliedVol)
-			
+
 			t.Logf("Original price: $%.6f", originalPrice)
 			t.Logf("Original vol:   %.6f (%.2f%%)", tc.volatility, tc.volatility*100)
 			t.Logf("Implied vol:    %.6f (%.2f%%)", impliedVol, impliedVol*100)
 			t.Logf("Round-trip price: $%.6f", roundTripPrice)
 			t.Logf("Price difference: $%.8f", priceDiff)
 			t.Logf("Vol difference:   %.8f (%.4f%%)", volDiff, volDiff*100)
-			
-			// Price should match within 0.01 (1 cent) - realistic tolerance
-			if priceDiff > 0.01 {
-				t.Errorf("Round-trip price difference too large: $%.6f > $0.01", priceDiff)
+
+			// The Newton/Brent solver over the closed-form Merton price should
+			// round-trip to sub-basis-point accuracy; a tolerance this tight
+			// would have failed under the old Taylor-series CDF / bisection
+			// solver, so it actually catches a regression in either.
+			if priceDiff > 1e-6 {
+				t.Errorf("Round-trip price difference too large: $%.10f > $0.000001", priceDiff)
 			}
-			
-			// Volatility should match within 0.001 (0.1%) - realistic tolerance  
-			if volDiff > 0.001 {
-				t.Errorf("Volatility difference too large: %.6f > 0.001", volDiff)
+
+			if volDiff > 1e-6 {
+				t.Errorf("Volatility difference too large: %.10f > 0.000001", volDiff)
 			}
-			
+
 			// Sanity checks
 			if originalPrice <= 0 {
 				t.Errorf("Original price should be positive: $%.6f", originalPrice)
@@ -135,7 +151,7 @@ func TestRoundTripEdgeCases(t *testing.T) {
 			volatility:   0.20,
 			riskFreeRate: 0.05,
 			contractType: "CALL",
-			tolerance:    0.001, // Slightly higher tolerance
+			tolerance:    1e-6,
 		},
 		{
 			name:         "Very deep OTM put",
@@ -145,7 +161,7 @@ func TestRoundTripEdgeCases(t *testing.T) {
 			volatility:   0.20,
 			riskFreeRate: 0.05,
 			contractType: "PUT",
-			tolerance:    0.001,
+			tolerance:    1e-6,
 		},
 		{
 			name:         "Near-zero volatility",
@@ -155,7 +171,7 @@ func TestRoundTripEdgeCases(t *testing.T) {
 			volatility:   0.01, // 1% IV
 			riskFreeRate: 0.05,
 			contractType: "PUT",
-			tolerance:    0.001,
+			tolerance:    1e-6,
 		},
 		{
 			name:         "Extreme high volatility",
@@ -165,35 +181,36 @@ func TestRoundTripEdgeCases(t *testing.T) {
 			volatility:   2.0, // 200% IV
 			riskFreeRate: 0.05,
 			contractType: "CALL",
-			tolerance:    0.01, // Higher tolerance for extreme vol
+			tolerance:    1e-6,
 		},
 	}
 
 	for _, tc := range edgeCases {
 		t.Run(tc.name, func(t *testing.T) {
 			originalPrice := BlackScholes(tc.underlying, tc.strike, tc.timeToExpiry, tc.volatility, tc.riskFreeRate, tc.contractType)
-			
+
 			// Skip if price is too small (numerical issues expected)
 			if originalPrice < 0.001 {
 				t.Skipf("Skipping case with very small price: $%.8f", originalPrice)
 			}
-			
-			impliedVol := GetImpliedVolatility(originalPrice, tc.underlying, tc.strike, tc.timeToExpiry, tc.riskFreeRate, tc.contractType, 0.0)
+
+			impliedVol, err := GetImpliedVolatility(originalPrice, tc.underlying, tc.strike, tc.timeToExpiry, tc.riskFreeRate, 0, tc.contractType, IVOptions{})
+			if err != nil {
+				t.Fatalf("GetImpliedVolatility() returned error: %v", err)
+			}
 			roundTripPrice := BlackScholes(tc.underlying, tc.strike, tc.timeToExpiry, impliedVol, tc.riskFreeRate, tc.contractType)
-			
+
 			priceDiff := math.Abs(originalPrice - roundTripPrice)
 			volDiff := math.Abs(tc.volatility - impliedVol)
-			
+
 			t.Logf("Original price: $%.6f", originalPrice)
 			t.Logf("Round-trip price: $%.6f", roundTripPrice)
 			t.Logf("Price difference: $%.8f (tolerance: $%.3f)", priceDiff, tc.tolerance)
 			t.Logf("Vol difference: %.6f", volDiff)
-			
+
 			if priceDiff > tc.tolerance {
 				t.Errorf("Price difference %.8f exceeds tolerance %.8f", priceDiff, tc.tolerance)
 			}
 		})
 	}
 }
-
-
@@ -0,0 +1,79 @@
+// Package surface fits an implied-volatility surface from a batch of quoted
+// option prices, turning the single-contract calculations in the parent
+// blackscholes package into something usable for market-making and risk on
+// a whole option chain.
+package surface
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/sf8193/blackscholes"
+)
+
+// Quote is a single quoted option price used to fit a volatility surface.
+type Quote struct {
+	S, K, T, R, Q, Price float64
+	Type                 string // "CALL" or "PUT"
+}
+
+// Surface exposes the fitted implied and local volatility at any
+// log-moneyness / maturity pair, where k = ln(K/F) for forward price F.
+type Surface interface {
+	// IV returns the Black-Scholes implied volatility at log-moneyness k
+	// and maturity t.
+	IV(k, t float64) float64
+	// LocalVol returns the Dupire local volatility at the same coordinates.
+	LocalVol(k, t float64) float64
+}
+
+// FitSVI fits a raw-SVI implied-volatility surface from a batch of quoted
+// option prices: one SVI slice per distinct maturity, each fit by nonlinear
+// least squares on the total implied variance produced by
+// blackscholes.GetImpliedVolatility. Maturities are then interpolated
+// linearly in total variance.
+func FitSVI(quotes []Quote) (Surface, error) {
+	if len(quotes) == 0 {
+		return nil, errors.New("surface: no quotes provided")
+	}
+
+	byMaturity := make(map[float64][]Quote)
+	for _, q := range quotes {
+		byMaturity[q.T] = append(byMaturity[q.T], q)
+	}
+
+	slices := make([]sviSlice, 0, len(byMaturity))
+	for t, qs := range byMaturity {
+		ks, ws, err := totalVariancePoints(qs, t)
+		if err != nil {
+			return nil, fmt.Errorf("surface: maturity %.4f: %w", t, err)
+		}
+		slices = append(slices, sviSlice{t: t, params: fitSVISlice(ks, ws)})
+	}
+
+	sort.Slice(slices, func(i, j int) bool { return slices[i].t < slices[j].t })
+	return &sviSurface{slices: slices}, nil
+}
+
+// totalVariancePoints converts a maturity's quotes into (log-moneyness,
+// total variance) points by inverting each quote's price to an implied
+// volatility and scaling sigma^2 by t. Quotes for which
+// GetImpliedVolatility returns any error (price outside no-arbitrage bounds
+// or no convergence) are skipped.
+func totalVariancePoints(quotes []Quote, t float64) (ks, ws []float64, err error) {
+	for _, q := range quotes {
+		iv, err := blackscholes.GetImpliedVolatility(q.Price, q.S, q.K, q.T, q.R, q.Q, q.Type, blackscholes.IVOptions{})
+		if err != nil {
+			continue
+		}
+		forward := q.S * math.Exp((q.R-q.Q)*t)
+		ks = append(ks, math.Log(q.K/forward))
+		ws = append(ws, iv*iv*t)
+	}
+	if len(ks) < 5 {
+		return nil, nil, fmt.Errorf("need at least 5 quotes with a valid implied vol, got %d", len(ks))
+	}
+	return ks, ws, nil
+}
@@ -0,0 +1,127 @@
+package surface
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sf8193/blackscholes"
+)
+
+func TestFitSVIRecoversFlatSmile(t *testing.T) {
+	s, r, q, vol, tExp := 100.0, 0.05, 0.0, 0.25, 0.5
+	strikes := []float64{80, 90, 100, 110, 120, 130}
+
+	var quotes []Quote
+	for _, k := range strikes {
+		quotes = append(quotes, Quote{
+			S: s, K: k, T: tExp, R: r, Q: q,
+			Price: blackscholes.BlackScholesMerton(s, k, tExp, vol, r, q, "CALL"),
+			Type:  "CALL",
+		})
+	}
+
+	surf, err := FitSVI(quotes)
+	if err != nil {
+		t.Fatalf("FitSVI() returned error: %v", err)
+	}
+
+	iv := surf.IV(0, tExp)
+	if math.Abs(iv-vol) > 0.05 {
+		t.Fatalf("ATM IV %f should be close to input vol %f", iv, vol)
+	}
+}
+
+func TestFitSVIRecoversFlatSmileWithDividendYield(t *testing.T) {
+	// A non-zero dividend yield shifts the forward used for log-moneyness;
+	// fitting should still recover the input vol rather than silently
+	// mispricing the smile.
+	s, r, q, vol, tExp := 100.0, 0.05, 0.06, 0.25, 0.5
+	strikes := []float64{80, 90, 100, 110, 120, 130}
+
+	var quotes []Quote
+	for _, k := range strikes {
+		quotes = append(quotes, Quote{
+			S: s, K: k, T: tExp, R: r, Q: q,
+			Price: blackscholes.BlackScholesMerton(s, k, tExp, vol, r, q, "CALL"),
+			Type:  "CALL",
+		})
+	}
+
+	surf, err := FitSVI(quotes)
+	if err != nil {
+		t.Fatalf("FitSVI() returned error: %v", err)
+	}
+
+	iv := surf.IV(0, tExp)
+	if math.Abs(iv-vol) > 0.05 {
+		t.Fatalf("ATM IV %f should be close to input vol %f with q=%.2f", iv, vol, q)
+	}
+}
+
+func TestFitSVIRejectsEmptyQuotes(t *testing.T) {
+	if _, err := FitSVI(nil); err == nil {
+		t.Fatalf("expected error for empty quotes")
+	}
+}
+
+func TestLocalVolNonZeroSingleSlice(t *testing.T) {
+	// A single quoted maturity should still produce a non-zero local vol at
+	// any t, including right at the fitted tenor, since the surface
+	// extrapolates at flat implied vol rather than flat total variance.
+	s, r, q, vol, tExp := 100.0, 0.05, 0.0, 0.25, 0.5
+	strikes := []float64{80, 90, 100, 110, 120, 130}
+
+	var quotes []Quote
+	for _, k := range strikes {
+		quotes = append(quotes, Quote{
+			S: s, K: k, T: tExp, R: r, Q: q,
+			Price: blackscholes.BlackScholesMerton(s, k, tExp, vol, r, q, "CALL"),
+			Type:  "CALL",
+		})
+	}
+
+	surf, err := FitSVI(quotes)
+	if err != nil {
+		t.Fatalf("FitSVI() returned error: %v", err)
+	}
+
+	for _, k := range []float64{-0.3, 0, 0.3} {
+		for _, tq := range []float64{0.1, tExp, 1.0} {
+			lv := surf.LocalVol(k, tq)
+			if lv <= 0 {
+				t.Fatalf("LocalVol(%v, %v) = %v, want > 0", k, tq, lv)
+			}
+		}
+	}
+}
+
+func TestLocalVolNonZeroOutsideFittedRange(t *testing.T) {
+	// With two maturities, querying just outside [t0, t1] must not collapse
+	// to zero local vol either.
+	s, r, q, vol := 100.0, 0.05, 0.0, 0.25
+	strikes := []float64{80, 90, 100, 110, 120, 130}
+	maturities := []float64{0.25, 0.75}
+
+	var quotes []Quote
+	for _, tExp := range maturities {
+		for _, k := range strikes {
+			quotes = append(quotes, Quote{
+				S: s, K: k, T: tExp, R: r, Q: q,
+				Price: blackscholes.BlackScholesMerton(s, k, tExp, vol, r, q, "CALL"),
+				Type:  "CALL",
+			})
+		}
+	}
+
+	surf, err := FitSVI(quotes)
+	if err != nil {
+		t.Fatalf("FitSVI() returned error: %v", err)
+	}
+
+	for _, tq := range []float64{0.1, 1.0} {
+		lv := surf.LocalVol(0, tq)
+		if lv <= 0 {
+			t.Fatalf("LocalVol(0, %v) = %v, want > 0", tq, lv)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package surface
+
+import "math"
+
+// sviParams holds the five raw-SVI parameters for one maturity slice:
+// w(k) = a + b*(rho*(k-m) + sqrt((k-m)^2 + sigma^2))
+type sviParams struct {
+	a, b, rho, m, sigma float64
+}
+
+// totalVariance evaluates the raw-SVI total variance w(k) for this slice.
+func (p sviParams) totalVariance(k float64) float64 {
+	diff := k - p.m
+	return p.a + p.b*(p.rho*diff+math.Sqrt(diff*diff+p.sigma*p.sigma))
+}
+
+// sviSlice is one fitted maturity slice of the surface.
+type sviSlice struct {
+	t      float64
+	params sviParams
+}
+
+// fitSVISlice fits the five raw-SVI parameters to the (k, w) points by
+// gradient descent on the sum-of-squares loss, with the gradient taken by
+// finite differences. SVI has no closed-form fit, and this keeps the
+// library dependency-free rather than pulling in a general NLS solver.
+func fitSVISlice(ks, ws []float64) sviParams {
+	meanW := 0.0
+	for _, w := range ws {
+		meanW += w
+	}
+	meanW /= float64(len(ws))
+
+	p := sviParams{a: meanW, b: 0.1, rho: 0, m: 0, sigma: 0.1}
+
+	loss := func(p sviParams) float64 {
+		sum := 0.0
+		for i, k := range ks {
+			d := p.totalVariance(k) - ws[i]
+			sum += d * d
+		}
+		return sum
+	}
+
+	const (
+		iterations = 500
+		step       = 1e-4
+		learnRate  = 0.05
+	)
+	for iter := 0; iter < iterations; iter++ {
+		grad := sviGradient(p, loss, step)
+		p.a -= learnRate * grad.a
+		p.b -= learnRate * grad.b
+		p.rho -= learnRate * grad.rho
+		p.m -= learnRate * grad.m
+		p.sigma -= learnRate * grad.sigma
+
+		// Keep parameters in the region where w(k) stays convex and finite:
+		// b >= 0 (wings don't invert), sigma > 0 (no singular vertex), and
+		// |rho| < 1 (valid correlation).
+		p.b = math.Max(p.b, 1e-6)
+		p.sigma = math.Max(p.sigma, 1e-6)
+		p.rho = math.Max(-0.999, math.Min(0.999, p.rho))
+	}
+	return p
+}
+
+// sviGradient computes the numerical (central finite-difference) gradient
+// of loss at p.
+func sviGradient(p sviParams, loss func(sviParams) float64, step float64) sviParams {
+	partial := func(field *float64) float64 {
+		orig := *field
+		*field = orig + step
+		up := loss(p)
+		*field = orig - step
+		down := loss(p)
+		*field = orig
+		return (up - down) / (2 * step)
+	}
+	return sviParams{
+		a:     partial(&p.a),
+		b:     partial(&p.b),
+		rho:   partial(&p.rho),
+		m:     partial(&p.m),
+		sigma: partial(&p.sigma),
+	}
+}
+
+// sviSurface is a Surface backed by per-maturity raw-SVI slices,
+// interpolated linearly in total variance across maturities.
+type sviSurface struct {
+	slices []sviSlice // sorted by t ascending
+}
+
+// IV implements Surface.
+func (s *sviSurface) IV(k, t float64) float64 {
+	if t <= 0 {
+		return 0
+	}
+	return math.Sqrt(s.totalVariance(k, t) / t)
+}
+
+// totalVariance interpolates total variance w(k,t) linearly in t across the
+// fitted SVI slices, evaluating each neighboring slice's SVI curve at k.
+// Outside the fitted maturity range it extrapolates the nearest slice at
+// flat implied volatility (not flat total variance), so w(k,t) still scales
+// linearly in t and LocalVol's t-derivative doesn't vanish there.
+func (s *sviSurface) totalVariance(k, t float64) float64 {
+	last := len(s.slices) - 1
+	if t <= s.slices[0].t {
+		return extrapolateFlatVol(s.slices[0], k, t)
+	}
+	if t >= s.slices[last].t {
+		return extrapolateFlatVol(s.slices[last], k, t)
+	}
+	for i := 0; i < last; i++ {
+		lo, hi := s.slices[i], s.slices[i+1]
+		if t >= lo.t && t <= hi.t {
+			wLo := lo.params.totalVariance(k)
+			wHi := hi.params.totalVariance(k)
+			frac := (t - lo.t) / (hi.t - lo.t)
+			return wLo + frac*(wHi-wLo)
+		}
+	}
+	return s.slices[last].params.totalVariance(k)
+}
+
+// extrapolateFlatVol extends slice's fitted smile to a maturity t outside
+// the fitted range by holding the implied volatility at each k constant,
+// i.e. w(k,t) = sigma(k)^2 * t. A single-slice surface, or any query before
+// the first / after the last quoted tenor, is extrapolated this way rather
+// than by holding w itself constant, which would make it (and its
+// t-derivative) flat at every queried t.
+func extrapolateFlatVol(slice sviSlice, k, t float64) float64 {
+	if slice.t <= 0 {
+		return slice.params.totalVariance(k)
+	}
+	sigma2 := slice.params.totalVariance(k) / slice.t
+	return sigma2 * t
+}
+
+// LocalVol implements Surface. It computes the Dupire local volatility via
+// Gatheral's formula expressed directly in terms of total variance w(k,t)
+// and its derivatives, which are taken by finite differences on
+// totalVariance.
+func (s *sviSurface) LocalVol(k, t float64) float64 {
+	const h = 1e-4
+	w := s.totalVariance(k, t)
+	if w <= 0 || t <= 2*h {
+		return math.Sqrt(math.Max(w, 0) / math.Max(t, h))
+	}
+
+	dwdt := (s.totalVariance(k, t+h) - s.totalVariance(k, t-h)) / (2 * h)
+	dwdk := (s.totalVariance(k+h, t) - s.totalVariance(k-h, t)) / (2 * h)
+	d2wdk2 := (s.totalVariance(k+h, t) - 2*w + s.totalVariance(k-h, t)) / (h * h)
+
+	denom := 1 - (k/w)*dwdk + 0.25*(-0.25-1/w+(k*k)/(w*w))*dwdk*dwdk + 0.5*d2wdk2
+	if denom <= 0 {
+		return math.Sqrt(w / t)
+	}
+	localVar := dwdt / denom
+	if localVar < 0 {
+		return math.Sqrt(w / t)
+	}
+	return math.Sqrt(localVar)
+}